@@ -0,0 +1,91 @@
+// Command go-local-ci runs `go test` across one or more targets, resolving
+// each to its enclosing Go module and classifying results into unit and
+// integration tiers before invocation.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/tettuan/go-local-ci/internal/classify"
+	"github.com/tettuan/go-local-ci/internal/discovery"
+)
+
+func main() {
+	code, err := run(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(code)
+}
+
+func run(args []string) (int, error) {
+	fs := flag.NewFlagSet("go-local-ci", flag.ContinueOnError)
+	continueOnTierFailure := fs.Bool("continue-on-tier-failure", false,
+		"run every tier even after an earlier tier fails")
+	if err := fs.Parse(args); err != nil {
+		return 0, err
+	}
+
+	targets := fs.Args()
+	if len(targets) == 0 {
+		targets = []string{"."}
+	}
+
+	runRoot, err := os.Getwd()
+	if err != nil {
+		return 0, fmt.Errorf("go-local-ci: %w", err)
+	}
+
+	plans, err := discovery.Resolve(runRoot, targets)
+	if err != nil {
+		return 0, err
+	}
+
+	code := 0
+	for _, plan := range plans {
+		pkgDirs, err := discovery.ListPackageDirs(plan.Module.Dir, plan.Args()...)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, pkgDir := range pkgDirs {
+			report, err := runPackage(pkgDir, *continueOnTierFailure)
+			if err != nil {
+				return 0, err
+			}
+			printReport(plan.Module.Path, pkgDir, report)
+			code |= report.ExitCode()
+		}
+	}
+	return code, nil
+}
+
+// runPackage classifies and runs the tests in a single package directory.
+func runPackage(pkgDir string, continueOnTierFailure bool) (*classify.Report, error) {
+	driver := classify.NewDriver(pkgDir, nil, classify.Config{}, continueOnTierFailure)
+	return driver.Run()
+}
+
+func printReport(modulePath, pkgDir string, report *classify.Report) {
+	fmt.Printf("%s (%s):\n", modulePath, pkgDir)
+	for _, tier := range []classify.Tier{classify.TierUnit, classify.TierIntegration} {
+		summary, ok := report.Tiers[tier]
+		if !ok {
+			continue
+		}
+		switch {
+		case summary.Skipped:
+			fmt.Printf("  %s: skipped (earlier tier failed)\n", tier)
+		case summary.BuildFailed:
+			fmt.Printf("  %s: build failed\n", tier)
+		default:
+			fmt.Printf("  %s: %d/%d passed (%s)\n", tier, summary.Total-summary.Failed, summary.Total, summary.Elapsed)
+			for _, failure := range summary.Failures {
+				fmt.Printf("    FAIL %s\n%s", failure.Test, failure.Excerpt)
+			}
+		}
+	}
+}
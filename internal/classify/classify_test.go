@@ -0,0 +1,253 @@
+package classify
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("MkdirAll(%s): %v", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile(%s): %v", name, err)
+	}
+}
+
+func TestClassifyTestFuncsBySuffix(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "main_unit_test.go", `package main
+
+import "testing"
+
+func TestBuggyAddUnit(t *testing.T) {}
+`)
+	writeTestFile(t, dir, "main_integration_test.go", `package main
+
+import "testing"
+
+func TestBuggyAddIntegration(t *testing.T) {}
+`)
+
+	got, err := ClassifyTestFuncs(dir, Config{})
+	if err != nil {
+		t.Fatalf("ClassifyTestFuncs: %v", err)
+	}
+	if got["TestBuggyAddUnit"] != TierUnit {
+		t.Errorf("TestBuggyAddUnit tier = %v, want TierUnit", got["TestBuggyAddUnit"])
+	}
+	if got["TestBuggyAddIntegration"] != TierIntegration {
+		t.Errorf("TestBuggyAddIntegration tier = %v, want TierIntegration", got["TestBuggyAddIntegration"])
+	}
+}
+
+func TestClassifyTestFuncsByBuildTag(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "slow_test.go", `//go:build integration
+
+package main
+
+import "testing"
+
+func TestSlowPath(t *testing.T) {}
+`)
+
+	got, err := ClassifyTestFuncs(dir, Config{})
+	if err != nil {
+		t.Fatalf("ClassifyTestFuncs: %v", err)
+	}
+	if got["TestSlowPath"] != TierIntegration {
+		t.Errorf("TestSlowPath tier = %v, want TierIntegration", got["TestSlowPath"])
+	}
+}
+
+func TestClassifyTestFuncsConfigOverridesSuffix(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "main_unit_test.go", `package main
+
+import "testing"
+
+func TestBuggyAddUnit(t *testing.T) {}
+`)
+
+	cfg := Config{PackageTier: map[string]Tier{filepath.Base(dir): TierIntegration}}
+	got, err := ClassifyTestFuncs(dir, cfg)
+	if err != nil {
+		t.Fatalf("ClassifyTestFuncs: %v", err)
+	}
+	if got["TestBuggyAddUnit"] != TierIntegration {
+		t.Errorf("TestBuggyAddUnit tier = %v, want TierIntegration (config override)", got["TestBuggyAddUnit"])
+	}
+}
+
+func TestReportExitCodeBitmask(t *testing.T) {
+	report := &Report{Tiers: map[Tier]*TierSummary{
+		TierUnit:        {Tier: TierUnit, Failed: 1},
+		TierIntegration: {Tier: TierIntegration, Failed: 0, BuildFailed: true},
+	}}
+
+	want := ExitUnitFailed | ExitBuildFailed
+	if got := report.ExitCode(); got != want {
+		t.Errorf("ExitCode() = %d, want %d", got, want)
+	}
+}
+
+func TestDriverShortCircuitsOnUnitFailure(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "main_unit_test.go", `package main
+
+import "testing"
+
+func TestUnitFails(t *testing.T) {}
+`)
+	writeTestFile(t, dir, "main_integration_test.go", `package main
+
+import "testing"
+
+func TestIntegrationRuns(t *testing.T) {}
+`)
+
+	d := NewDriver(dir, nil, Config{}, false)
+	var ran []Tier
+	d.runTier = func(tier Tier, names []string) (*TierSummary, error) {
+		ran = append(ran, tier)
+		return &TierSummary{Tier: tier, Total: len(names), Failed: len(names)}, nil
+	}
+
+	report, err := d.Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	sort.Slice(ran, func(i, j int) bool { return ran[i] < ran[j] })
+	if len(ran) != 1 || ran[0] != TierUnit {
+		t.Errorf("ran tiers = %v, want only [TierUnit]", ran)
+	}
+	if !report.Tiers[TierIntegration].Skipped {
+		t.Errorf("integration tier should be marked Skipped")
+	}
+}
+
+func TestSummarizeDoesNotDoubleCountSubtests(t *testing.T) {
+	events := []testEvent{
+		{Action: "run", Test: "TestMatrix"},
+		{Action: "run", Test: "TestMatrix/case1"},
+		{Action: "pass", Test: "TestMatrix/case1", Elapsed: 0.01},
+		{Action: "run", Test: "TestMatrix/case2"},
+		{Action: "fail", Test: "TestMatrix/case2", Elapsed: 0.02},
+		{Action: "fail", Test: "TestMatrix", Elapsed: 0.03},
+	}
+
+	summary := summarize(TierUnit, events)
+	if summary.Total != 2 {
+		t.Errorf("Total = %d, want 2 (the parent's own result must not be counted alongside its subtests)", summary.Total)
+	}
+	if summary.Failed != 1 {
+		t.Errorf("Failed = %d, want 1", summary.Failed)
+	}
+}
+
+func TestDriverDoesNotDoubleCountSubtestsEndToEnd(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "go.mod", "module example.com/matrix\n\ngo 1.21\n")
+	writeTestFile(t, dir, "matrix_test.go", `package matrix
+
+import "testing"
+
+func TestMatrix(t *testing.T) {
+	cases := []struct {
+		name string
+		ok   bool
+	}{
+		{"case1", true},
+		{"case2", true},
+		{"case3", true},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			if !c.ok {
+				t.Fatal("unexpected failure")
+			}
+		})
+	}
+}
+`)
+
+	d := NewDriver(dir, nil, Config{}, false)
+	report, err := d.Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	summary := report.Tiers[TierUnit]
+	if summary == nil {
+		t.Fatal("expected a TierUnit summary")
+	}
+	if summary.Total != 3 {
+		t.Errorf("Total = %d, want 3 (one per subtest, not counting the parent too)", summary.Total)
+	}
+}
+
+func TestDriverActuallyRunsBuildTagGatedIntegrationTests(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "go.mod", "module example.com/tagged\n\ngo 1.21\n")
+	writeTestFile(t, dir, "slow_test.go", `//go:build integration
+
+package tagged
+
+import "testing"
+
+func TestSlowPath(t *testing.T) {
+	t.Fatal("intentionally wrong: exercises the integration tier")
+}
+`)
+
+	d := NewDriver(dir, nil, Config{}, false)
+	report, err := d.Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	summary := report.Tiers[TierIntegration]
+	if summary == nil {
+		t.Fatal("expected a TierIntegration summary")
+	}
+	if summary.Total != 1 || summary.Failed != 1 {
+		t.Errorf("summary = %+v, want Total=1 Failed=1 (the tag-gated test must actually compile and run)", summary)
+	}
+}
+
+func TestDriverContinueOnTierFailureRunsAllTiers(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "main_unit_test.go", `package main
+
+import "testing"
+
+func TestUnitFails(t *testing.T) {}
+`)
+	writeTestFile(t, dir, "main_integration_test.go", `package main
+
+import "testing"
+
+func TestIntegrationRuns(t *testing.T) {}
+`)
+
+	d := NewDriver(dir, nil, Config{}, true)
+	var ran []Tier
+	d.runTier = func(tier Tier, names []string) (*TierSummary, error) {
+		ran = append(ran, tier)
+		return &TierSummary{Tier: tier, Total: len(names), Failed: len(names)}, nil
+	}
+
+	if _, err := d.Run(); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(ran) != 2 {
+		t.Errorf("ran tiers = %v, want both tiers run", ran)
+	}
+}
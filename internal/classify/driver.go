@@ -0,0 +1,97 @@
+package classify
+
+import "sort"
+
+// Driver classifies the tests in a package directory and runs each tier in
+// order (unit, then integration), short-circuiting later tiers once an
+// earlier one fails unless ContinueOnTierFailure is set.
+type Driver struct {
+	// Dir is the package directory to classify and test, e.g. an absolute
+	// directory returned by discovery.ListPackageDirs.
+	Dir string
+	// Args is passed through to every `go test` invocation, e.g. "./...".
+	Args []string
+	// Config overrides tier inference for specific packages.
+	Config Config
+	// ContinueOnTierFailure runs every tier regardless of earlier failures,
+	// instead of stopping at the first failing tier.
+	ContinueOnTierFailure bool
+
+	// runTier executes one tier and returns its summary. NewDriver wires it
+	// to the real `go test -json` implementation; tests override it.
+	runTier func(tier Tier, testNames []string) (*TierSummary, error)
+}
+
+// NewDriver returns a Driver that runs real `go test -json` invocations in
+// dir.
+func NewDriver(dir string, args []string, cfg Config, continueOnTierFailure bool) *Driver {
+	d := &Driver{
+		Dir:                   dir,
+		Args:                  args,
+		Config:                cfg,
+		ContinueOnTierFailure: continueOnTierFailure,
+	}
+	d.runTier = d.execTier
+	return d
+}
+
+func (d *Driver) execTier(tier Tier, testNames []string) (*TierSummary, error) {
+	args := append(append([]string{}, d.Args...), "-run", runPattern(testNames))
+	if tier == TierIntegration {
+		// Files classified as TierIntegration via a "//go:build integration"
+		// tag are otherwise excluded from compilation entirely, which would
+		// make this tier falsely report 0/0 passed instead of running them.
+		args = append(args, "-tags", integrationBuildTag)
+	}
+	events, err := execGoTest(d.Dir, args)
+	if err != nil && len(events) == 0 {
+		return &TierSummary{Tier: tier, BuildFailed: true}, nil
+	}
+	return summarize(tier, events), nil
+}
+
+// tierOrder is the sequence tiers run in; TierBuild has no test funcs of
+// its own and is never run directly.
+var tierOrder = []Tier{TierUnit, TierIntegration}
+
+// Run classifies every test in d.Dir, then runs each tier that has at
+// least one test, in tierOrder, stopping after the first tier with
+// failures unless d.ContinueOnTierFailure is set.
+func (d *Driver) Run() (*Report, error) {
+	testTiers, err := ClassifyTestFuncs(d.Dir, d.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	byTier := map[Tier][]string{}
+	for name, tier := range testTiers {
+		byTier[tier] = append(byTier[tier], name)
+	}
+	for _, names := range byTier {
+		sort.Strings(names)
+	}
+
+	report := &Report{Tiers: map[Tier]*TierSummary{}}
+	blocked := false
+	for _, tier := range tierOrder {
+		names := byTier[tier]
+		if len(names) == 0 {
+			continue
+		}
+
+		if blocked && !d.ContinueOnTierFailure {
+			report.Tiers[tier] = &TierSummary{Tier: tier, Skipped: true}
+			continue
+		}
+
+		summary, err := d.runTier(tier, names)
+		if err != nil {
+			return nil, err
+		}
+		report.Tiers[tier] = summary
+		if summary.Failed > 0 || summary.BuildFailed {
+			blocked = true
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,114 @@
+package classify
+
+import (
+	"bytes"
+	"encoding/json"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// testEvent mirrors one line of `go test -json` output, as documented by
+// `go help test`.
+type testEvent struct {
+	Action  string
+	Test    string
+	Elapsed float64
+	Output  string
+}
+
+// execGoTest runs `go test -json` plus extraArgs with dir as the working
+// directory and decodes the resulting event stream. It returns the parsed
+// events even when the command itself exits non-zero, since a non-empty
+// event stream means the tests ran (and simply failed); a command error
+// with no events means the package failed to build.
+func execGoTest(dir string, extraArgs []string) ([]testEvent, error) {
+	args := append([]string{"test", "-json"}, extraArgs...)
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stdout
+
+	runErr := cmd.Run()
+
+	var events []testEvent
+	decoder := json.NewDecoder(bytes.NewReader(stdout.Bytes()))
+	for {
+		var ev testEvent
+		if err := decoder.Decode(&ev); err != nil {
+			break
+		}
+		events = append(events, ev)
+	}
+	return events, runErr
+}
+
+// runPattern builds a `-run` regexp matching exactly the given test names.
+func runPattern(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = regexp.QuoteMeta(name)
+	}
+	return "^(" + strings.Join(quoted, "|") + ")$"
+}
+
+// summarize builds a TierSummary from the `go test -json` events belonging
+// to a single tier's run. `go test -json` emits a pass/fail event for a
+// table-driven test's t.Run subtests *and* for the parent test itself, so
+// counting every event would double-count: a parent with N subtests would
+// add 1+N results to Total instead of N. summarize counts only leaf
+// events — a test with no subtests of its own — so each independently
+// pass/failing case is counted exactly once.
+func summarize(tier Tier, events []testEvent) *TierSummary {
+	hasSubtest := map[string]bool{}
+	for _, ev := range events {
+		if idx := strings.LastIndex(ev.Test, "/"); idx >= 0 {
+			hasSubtest[ev.Test[:idx]] = true
+		}
+	}
+
+	summary := &TierSummary{Tier: tier}
+	output := map[string][]string{}
+
+	for _, ev := range events {
+		if ev.Test == "" {
+			continue
+		}
+		switch ev.Action {
+		case "output":
+			output[ev.Test] = append(output[ev.Test], ev.Output)
+		case "pass":
+			if hasSubtest[ev.Test] {
+				continue
+			}
+			summary.Total++
+			summary.Elapsed += durationFromSeconds(ev.Elapsed)
+		case "fail":
+			if hasSubtest[ev.Test] {
+				continue
+			}
+			summary.Total++
+			summary.Failed++
+			summary.Elapsed += durationFromSeconds(ev.Elapsed)
+			summary.Failures = append(summary.Failures, Failure{
+				Test:    ev.Test,
+				Excerpt: excerptFor(output[ev.Test]),
+			})
+		}
+	}
+	return summary
+}
+
+func excerptFor(lines []string) string {
+	if len(lines) > excerptLines {
+		lines = lines[:excerptLines]
+	}
+	return strings.Join(lines, "")
+}
+
+func durationFromSeconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,66 @@
+package classify
+
+import "time"
+
+// excerptLines caps how many lines of failure output a TierSummary keeps
+// per failed test.
+const excerptLines = 10
+
+// Failure is one failed test within a tier.
+type Failure struct {
+	Test    string
+	Excerpt string
+}
+
+// TierSummary reports the outcome of running a single tier.
+type TierSummary struct {
+	Tier    Tier
+	Total   int
+	Failed  int
+	Elapsed time.Duration
+
+	// Failures holds the first-failure excerpt for each failed test, in the
+	// order they were reported.
+	Failures []Failure
+
+	// BuildFailed is set when the tier's package failed to compile, so no
+	// individual test results are available.
+	BuildFailed bool
+
+	// Skipped is set when an earlier tier failed and
+	// Driver.ContinueOnTierFailure was false, so this tier was never run.
+	Skipped bool
+}
+
+// Report is the result of running every tier the classifier found tests
+// for.
+type Report struct {
+	Tiers map[Tier]*TierSummary
+}
+
+// Exit code bits, one per tier, composable so a wrapping script can tell
+// which tiers failed from a single process exit code.
+const (
+	ExitUnitFailed        = 1 << 0
+	ExitIntegrationFailed = 1 << 1
+	ExitBuildFailed       = 1 << 2
+)
+
+// ExitCode returns the composite exit code for the report: each failed
+// tier contributes its own bit, so callers can distinguish "unit failed"
+// from "integration failed" from a single integer.
+func (r *Report) ExitCode() int {
+	code := 0
+	if s := r.Tiers[TierUnit]; s != nil && s.Failed > 0 {
+		code |= ExitUnitFailed
+	}
+	if s := r.Tiers[TierIntegration]; s != nil && s.Failed > 0 {
+		code |= ExitIntegrationFailed
+	}
+	for _, s := range r.Tiers {
+		if s.BuildFailed {
+			code |= ExitBuildFailed
+		}
+	}
+	return code
+}
@@ -0,0 +1,136 @@
+package classify
+
+import (
+	"go/ast"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// ClassifyTestFuncs parses every *_test.go file directly inside pkgDir and
+// returns the tier each top-level TestXxx(t *testing.T) function belongs
+// to. Classification is, in priority order: an explicit cfg.PackageTier
+// match for pkgDir, the file's build tag, then its filename suffix
+// ("_unit_test.go" / "_integration_test.go"). A file matching none of these
+// defaults to TierUnit.
+func ClassifyTestFuncs(pkgDir string, cfg Config) (map[string]Tier, error) {
+	entries, err := os.ReadDir(pkgDir)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgTier, pkgOverride := cfg.tierForPackage(pkgDir)
+
+	funcs := map[string]Tier{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		path := filepath.Join(pkgDir, entry.Name())
+
+		tier := pkgTier
+		if !pkgOverride {
+			tier = fileTier(path, entry.Name())
+		}
+
+		names, err := testFuncNames(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			funcs[name] = tier
+		}
+	}
+	return funcs, nil
+}
+
+// fileTier infers a tier from path's build tag, falling back to its
+// filename suffix, then TierUnit.
+func fileTier(path, name string) Tier {
+	if tier, ok := buildTagTier(path); ok {
+		return tier
+	}
+	switch {
+	case strings.HasSuffix(name, "_integration_test.go"):
+		return TierIntegration
+	case strings.HasSuffix(name, "_unit_test.go"):
+		return TierUnit
+	default:
+		return TierUnit
+	}
+}
+
+// buildTagTier inspects the leading build-constraint comments of path for
+// a tag naming "integration". It only checks for that literal tag name,
+// not general boolean satisfiability of compound constraints.
+func buildTagTier(path string) (Tier, bool) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, nil, parser.PackageClauseOnly|parser.ParseComments)
+	if err != nil {
+		return TierUnit, false
+	}
+
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			if !constraint.IsGoBuild(c.Text) && !constraint.IsPlusBuild(c.Text) {
+				continue
+			}
+			expr, err := constraint.Parse(c.Text)
+			if err != nil {
+				continue
+			}
+			tags := strings.FieldsFunc(expr.String(), func(r rune) bool {
+				return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.'
+			})
+			for _, tag := range tags {
+				if tag == integrationBuildTag {
+					return TierIntegration, true
+				}
+			}
+		}
+	}
+	return TierUnit, false
+}
+
+// testFuncNames returns the name of every top-level func in path with the
+// standard `func TestXxx(t *testing.T)` signature.
+func testFuncNames(path string) ([]string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || !strings.HasPrefix(fn.Name.Name, "Test") {
+			continue
+		}
+		if fn.Type.Params == nil || len(fn.Type.Params.List) != 1 {
+			continue
+		}
+		if !isTestingTParam(fn.Type.Params.List[0].Type) {
+			continue
+		}
+		names = append(names, fn.Name.Name)
+	}
+	return names, nil
+}
+
+func isTestingTParam(expr ast.Expr) bool {
+	star, ok := expr.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "testing" && sel.Sel.Name == "T"
+}
@@ -0,0 +1,64 @@
+// Package classify groups Go test results into tiers (unit, integration,
+// build) so a wrapping shell script or git hook can react to each
+// differently, e.g. blocking a commit on unit failures but only warning on
+// integration failures.
+package classify
+
+import "path/filepath"
+
+// integrationBuildTag is the literal build-tag name buildTagTier looks for
+// to classify a file as TierIntegration, and the tag Driver passes to
+// `go test -tags` so those files are actually compiled in when the
+// integration tier runs.
+const integrationBuildTag = "integration"
+
+// Tier identifies which quality gate a test belongs to.
+type Tier int
+
+const (
+	// TierUnit is the default tier for tests with no other classification.
+	TierUnit Tier = iota
+	// TierIntegration covers tests classified via the "_integration_test.go"
+	// filename suffix, a "//go:build integration" tag, or an explicit
+	// Config.PackageTier entry.
+	TierIntegration
+	// TierBuild is not assigned to individual tests or set on any
+	// TierSummary; a package that `go test` fails to compile is reported by
+	// TierSummary.BuildFailed on the tier that was running.
+	TierBuild
+)
+
+// String returns the lowercase tier name used in summaries and flags.
+func (t Tier) String() string {
+	switch t {
+	case TierUnit:
+		return "unit"
+	case TierIntegration:
+		return "integration"
+	case TierBuild:
+		return "build"
+	default:
+		return "unknown"
+	}
+}
+
+// Config lets callers override tier inference for specific packages,
+// keyed by a filepath.Match glob matched against the package directory.
+// An explicit entry always wins over build-tag or filename inference.
+type Config struct {
+	PackageTier map[string]Tier
+}
+
+// tierForPackage returns the tier cfg assigns to pkgDir, if any glob in
+// cfg.PackageTier matches it.
+func (cfg Config) tierForPackage(pkgDir string) (Tier, bool) {
+	for glob, tier := range cfg.PackageTier {
+		if ok, err := filepath.Match(glob, pkgDir); err == nil && ok {
+			return tier, true
+		}
+		if ok, err := filepath.Match(glob, filepath.Base(pkgDir)); err == nil && ok {
+			return tier, true
+		}
+	}
+	return TierUnit, false
+}
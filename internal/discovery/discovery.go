@@ -0,0 +1,262 @@
+// Package discovery locates the Go module enclosing a CI target and
+// rewrites bare directory or package arguments into the form the go
+// toolchain expects, so callers never hand `go test` something that would
+// otherwise fail with "package XXX is not in GOROOT".
+package discovery
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Module describes a Go module discovered on disk by locating its go.mod.
+type Module struct {
+	// Path is the module path declared by the `module` directive.
+	Path string
+	// Dir is the absolute directory containing the module's go.mod.
+	Dir string
+}
+
+// NoModuleError is returned when a target does not resolve to any enclosing
+// Go module. This is the exact condition that otherwise surfaces as a raw
+// "package XXX is not in GOROOT" error from the go toolchain.
+type NoModuleError struct {
+	Target string
+}
+
+func (e *NoModuleError) Error() string {
+	return fmt.Sprintf(
+		"go-local-ci: %q does not belong to any Go module (no go.mod in any parent directory); "+
+			"the go toolchain would otherwise fail this with \"package is not in GOROOT\"",
+		e.Target,
+	)
+}
+
+// FindModule walks upward from dir looking for the nearest go.mod and
+// returns the module it declares. It returns a *NoModuleError if no go.mod
+// is found before reaching the filesystem root.
+func FindModule(dir string) (*Module, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("go-local-ci: resolving %q: %w", dir, err)
+	}
+
+	for current := abs; ; {
+		modFile := filepath.Join(current, "go.mod")
+		data, err := os.ReadFile(modFile)
+		switch {
+		case err == nil:
+			path, perr := parseModulePath(data)
+			if perr != nil {
+				return nil, fmt.Errorf("go-local-ci: parsing %s: %w", modFile, perr)
+			}
+			return &Module{Path: path, Dir: current}, nil
+		case !os.IsNotExist(err):
+			return nil, fmt.Errorf("go-local-ci: reading %s: %w", modFile, err)
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return nil, &NoModuleError{Target: dir}
+		}
+		current = parent
+	}
+}
+
+// discoverModulesUnder walks downward from dir looking for go.mod files,
+// so a directory that is itself a module (or the parent of several) can be
+// dispatched as one or more independent modules instead of erroring or
+// falling back to an unrelated ancestor module. It stops descending into a
+// subtree as soon as it finds a go.mod there, since a module's own nested
+// directories belong to that same module. Returns no modules (and no
+// error) when dir and everything under it is module-free, so callers can
+// fall back to walking upward from dir instead.
+func discoverModulesUnder(dir string) ([]*Module, error) {
+	var modules []*Module
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		data, rerr := os.ReadFile(filepath.Join(path, "go.mod"))
+		switch {
+		case rerr == nil:
+			modPath, perr := parseModulePath(data)
+			if perr != nil {
+				return fmt.Errorf("go-local-ci: parsing %s: %w", filepath.Join(path, "go.mod"), perr)
+			}
+			modules = append(modules, &Module{Path: modPath, Dir: path})
+			return filepath.SkipDir
+		case !os.IsNotExist(rerr):
+			return fmt.Errorf("go-local-ci: reading %s: %w", filepath.Join(path, "go.mod"), rerr)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return modules, nil
+}
+
+func parseModulePath(gomod []byte) (string, error) {
+	scanner := bufio.NewScanner(strings.NewReader(string(gomod)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "module" {
+			return fields[1], nil
+		}
+	}
+	return "", fmt.Errorf("no module directive found")
+}
+
+// Target is a single CI invocation target, rewritten from the original
+// argument into the forms `go test` accepts.
+type Target struct {
+	// Arg is the original, possibly bare, argument (e.g. "pkg/math").
+	Arg string
+	// Module is the module Arg resolved against.
+	Module *Module
+	// RelImport is the "./relative/..." form, valid when the go toolchain is
+	// invoked with Module.Dir as its working directory.
+	RelImport string
+	// ImportPath is the fully qualified form (Module.Path joined with the
+	// path relative to the module root), valid from any working directory.
+	ImportPath string
+}
+
+// Plan groups the targets belonging to a single module, since each module
+// must be dispatched independently with its own working directory rather
+// than folded into one `go test ./...` at the repo root.
+type Plan struct {
+	Module  *Module
+	Targets []Target
+}
+
+// Args returns the "./relative/..." form of each target in the plan, ready
+// to pass to `go test` when invoked with Plan.Module.Dir as the working
+// directory.
+func (p Plan) Args() []string {
+	args := make([]string, len(p.Targets))
+	for i, t := range p.Targets {
+		args[i] = t.RelImport
+	}
+	return args
+}
+
+// Resolve walks each of args (interpreted relative to runRoot when not
+// already absolute), finds the module(s) each belongs to, and groups the
+// rewritten targets into one Plan per module. An arg is resolved by first
+// scanning downward for go.mod files under it: this is what lets a single
+// arg naming a directory of several sibling modules (a multi-module repo
+// laid out under one parent, e.g. a "tests/fixtures" directory of one
+// module per fixture) dispatch as independent Plans instead of an error or
+// a false match against an unrelated ancestor module. Only when nothing is
+// found downward does Resolve fall back to walking upward from arg, which
+// is what resolves a bare in-module target like "pkg/math". The returned
+// plans are ordered by first occurrence of their module in args.
+func Resolve(runRoot string, args []string) ([]Plan, error) {
+	plansByDir := map[string]*Plan{}
+	var order []string
+
+	addTarget := func(arg string, mod *Module, dir string) error {
+		rel, err := filepath.Rel(mod.Dir, dir)
+		if err != nil {
+			return fmt.Errorf("go-local-ci: relativizing %q against module root %q: %w", dir, mod.Dir, err)
+		}
+		rel = filepath.ToSlash(rel)
+
+		relImport, importPath := "./...", mod.Path
+		if rel != "." {
+			relImport = "./" + rel
+			importPath = mod.Path + "/" + rel
+		}
+
+		plan, ok := plansByDir[mod.Dir]
+		if !ok {
+			plan = &Plan{Module: mod}
+			plansByDir[mod.Dir] = plan
+			order = append(order, mod.Dir)
+		}
+		plan.Targets = append(plan.Targets, Target{
+			Arg:        arg,
+			Module:     mod,
+			RelImport:  relImport,
+			ImportPath: importPath,
+		})
+		return nil
+	}
+
+	for _, arg := range args {
+		dir := arg
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(runRoot, dir)
+		}
+
+		mods, err := discoverModulesUnder(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(mods) == 0 {
+			mod, err := FindModule(dir)
+			if err != nil {
+				return nil, err
+			}
+			if err := addTarget(arg, mod, dir); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		for _, mod := range mods {
+			if err := addTarget(arg, mod, mod.Dir); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	plans := make([]Plan, 0, len(order))
+	for _, dir := range order {
+		plans = append(plans, *plansByDir[dir])
+	}
+	return plans, nil
+}
+
+// ListPackageDirs expands patterns (as returned by Plan.Args, e.g.
+// "./..." or "./pkg/math") into the absolute directories of the packages
+// they match, by asking the go tool directly rather than re-deriving
+// directories by hand from the rewritten import strings. moduleDir is used
+// as the working directory, matching how the patterns were rewritten.
+func ListPackageDirs(moduleDir string, patterns ...string) ([]string, error) {
+	listArgs := append([]string{"list", "-f", "{{.Dir}}"}, patterns...)
+	cmd := exec.Command("go", listArgs...)
+	cmd.Dir = moduleDir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		// Report go list's own explanation (e.g. "no Go files in ...") rather
+		// than just the bare exit status, so a resolution mistake surfaces as
+		// a diagnostic instead of an opaque toolchain failure.
+		return nil, fmt.Errorf("go-local-ci: go list %s in %s: %s", strings.Join(patterns, " "), moduleDir, strings.TrimSpace(stderr.String()))
+	}
+
+	var dirs []string
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		if line != "" {
+			dirs = append(dirs, line)
+		}
+	}
+	return dirs, nil
+}
@@ -0,0 +1,173 @@
+package discovery
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeModule(t *testing.T, dir, modulePath string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("MkdirAll(%s): %v", dir, err)
+	}
+	gomod := "module " + modulePath + "\n\ngo 1.21\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0o644); err != nil {
+		t.Fatalf("WriteFile go.mod: %v", err)
+	}
+}
+
+func TestFindModuleWalksUpward(t *testing.T) {
+	root := t.TempDir()
+	writeModule(t, root, "example.com/foo")
+
+	sub := filepath.Join(root, "pkg", "math")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatalf("MkdirAll(%s): %v", sub, err)
+	}
+
+	mod, err := FindModule(sub)
+	if err != nil {
+		t.Fatalf("FindModule: %v", err)
+	}
+	if mod.Path != "example.com/foo" {
+		t.Errorf("Path = %q, want %q", mod.Path, "example.com/foo")
+	}
+	if mod.Dir != root {
+		t.Errorf("Dir = %q, want %q", mod.Dir, root)
+	}
+}
+
+func TestFindModuleNoModule(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := FindModule(dir)
+	var noMod *NoModuleError
+	if !errors.As(err, &noMod) {
+		t.Fatalf("err = %v, want *NoModuleError", err)
+	}
+}
+
+func TestResolveRewritesBareTargets(t *testing.T) {
+	root := t.TempDir()
+	writeModule(t, root, "example.com/foo")
+	if err := os.MkdirAll(filepath.Join(root, "pkg", "math"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	plans, err := Resolve(root, []string{"pkg/math", "."})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if len(plans) != 1 {
+		t.Fatalf("len(plans) = %d, want 1", len(plans))
+	}
+
+	plan := plans[0]
+	if plan.Module.Path != "example.com/foo" {
+		t.Errorf("Module.Path = %q, want %q", plan.Module.Path, "example.com/foo")
+	}
+
+	got := plan.Args()
+	want := []string{"./pkg/math", "./..."}
+	if len(got) != len(want) {
+		t.Fatalf("Args() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Args()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if plan.Targets[0].ImportPath != "example.com/foo/pkg/math" {
+		t.Errorf("ImportPath = %q, want %q", plan.Targets[0].ImportPath, "example.com/foo/pkg/math")
+	}
+}
+
+func TestResolveDispatchesMultiModuleSeparately(t *testing.T) {
+	root := t.TempDir()
+	writeModule(t, filepath.Join(root, "service-a"), "example.com/service-a")
+	writeModule(t, filepath.Join(root, "service-b"), "example.com/service-b")
+
+	plans, err := Resolve(root, []string{"service-a", "service-b"})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if len(plans) != 2 {
+		t.Fatalf("len(plans) = %d, want 2", len(plans))
+	}
+	if plans[0].Module.Path != "example.com/service-a" || plans[1].Module.Path != "example.com/service-b" {
+		t.Errorf("unexpected module order: %q, %q", plans[0].Module.Path, plans[1].Module.Path)
+	}
+}
+
+func TestResolveDiscoversNestedModulesUnderAnUnrelatedDir(t *testing.T) {
+	root := t.TempDir()
+	writeModule(t, root, "example.com/outer")
+	writeModule(t, filepath.Join(root, "fixtures", "one"), "example.com/fixtures/one")
+	writeModule(t, filepath.Join(root, "fixtures", "two"), "example.com/fixtures/two")
+
+	plans, err := Resolve(root, []string{"fixtures"})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if len(plans) != 2 {
+		t.Fatalf("len(plans) = %d, want 2 (fixtures/one and fixtures/two, not example.com/outer)", len(plans))
+	}
+
+	got := map[string]bool{}
+	for _, plan := range plans {
+		got[plan.Module.Path] = true
+	}
+	for _, want := range []string{"example.com/fixtures/one", "example.com/fixtures/two"} {
+		if !got[want] {
+			t.Errorf("plans = %v, want to include module %q", got, want)
+		}
+	}
+}
+
+func TestListPackageDirsExpandsEllipsis(t *testing.T) {
+	root := t.TempDir()
+	writeModule(t, root, "example.com/foo")
+
+	mathDir := filepath.Join(root, "pkg", "math")
+	if err := os.MkdirAll(mathDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(mathDir, "math.go"), []byte("package math\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dirs, err := ListPackageDirs(root, "./...")
+	if err != nil {
+		t.Fatalf("ListPackageDirs: %v", err)
+	}
+
+	want := map[string]bool{root: true, mathDir: true}
+	got := map[string]bool{}
+	for _, dir := range dirs {
+		got[dir] = true
+	}
+	for dir := range want {
+		if !got[dir] {
+			t.Errorf("ListPackageDirs(%q) = %v, want it to include %q", "./...", dirs, dir)
+		}
+	}
+}
+
+func TestResolveNoModuleSurfacesDiagnostic(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "orphan"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Resolve(root, []string{"orphan"})
+	var noMod *NoModuleError
+	if !errors.As(err, &noMod) {
+		t.Fatalf("err = %v, want *NoModuleError", err)
+	}
+}
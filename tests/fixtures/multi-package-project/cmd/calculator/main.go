@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"log"
 
 	"example.com/multi-package/pkg/math"
 )
@@ -13,8 +14,25 @@ func main() {
 	fmt.Printf("===============\n")
 	fmt.Printf("a = %d, b = %d\n\n", a, b)
 
-	fmt.Printf("Add: %d + %d = %d\n", a, b, math.Add(a, b))
-	fmt.Printf("Subtract: %d - %d = %d\n", a, b, math.Subtract(a, b))
-	fmt.Printf("Multiply: %d * %d = %d\n", a, b, math.Multiply(a, b))
-	fmt.Printf("Divide: %d / %d = %.2f\n", a, b, math.Divide(float64(a), float64(b)))
+	sum, err := math.Add(a, b)
+	must(err)
+	fmt.Printf("Add: %d + %d = %v\n", a, b, sum)
+
+	diff, err := math.Sub(a, b)
+	must(err)
+	fmt.Printf("Subtract: %d - %d = %v\n", a, b, diff)
+
+	product, err := math.Mul(a, b)
+	must(err)
+	fmt.Printf("Multiply: %d * %d = %v\n", a, b, product)
+
+	quotient, err := math.Div(float64(a), float64(b))
+	must(err)
+	fmt.Printf("Divide: %d / %d = %.2f\n", a, b, quotient)
+}
+
+func must(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
 }
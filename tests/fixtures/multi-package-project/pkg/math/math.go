@@ -1,24 +1,207 @@
+// Package math provides a small arithmetic API that dispatches on the
+// reflected kind of its operands, so callers can mix signed integers,
+// unsigned integers, floats, and numeric strings without picking a
+// type-specific function.
 package math
 
-// Add adds two integers
-func Add(a, b int) int {
-	return a + b
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Operator runes accepted by Arithmetic.
+const (
+	OpAdd rune = '+'
+	OpSub rune = '-'
+	OpMul rune = '*'
+	OpDiv rune = '/'
+)
+
+// ErrDivideByZero is returned by Arithmetic (and its Div wrapper) instead
+// of silently producing a zero result.
+var ErrDivideByZero = errors.New("math: division by zero")
+
+// UnsupportedTypeError reports an operand Arithmetic does not know how to
+// convert to a number.
+type UnsupportedTypeError struct {
+	Value interface{}
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("math: unsupported operand type %T", e.Value)
+}
+
+// UnsupportedOpError reports an operator rune Arithmetic does not
+// recognize.
+type UnsupportedOpError struct {
+	Op rune
+}
+
+func (e *UnsupportedOpError) Error() string {
+	return fmt.Sprintf("math: unsupported operator %q", e.Op)
 }
 
-// Subtract subtracts b from a
-func Subtract(a, b int) int {
-	return a - b
+// kind classifies an operand's reflected numeric family.
+type kind int
+
+const (
+	kindSigned kind = iota
+	kindUnsigned
+	kindFloat
+)
+
+// numeric is an operand normalized to its kind's native width.
+type numeric struct {
+	kind kind
+	i    int64
+	u    uint64
+	f    float64
+}
+
+func (n numeric) asFloat() float64 {
+	switch n.kind {
+	case kindSigned:
+		return float64(n.i)
+	case kindUnsigned:
+		return float64(n.u)
+	default:
+		return n.f
+	}
+}
+
+// Arithmetic applies op to a and b, reflecting on each operand's kind to
+// pick the narrowest safe computation: signed int64 when both operands are
+// signed integers, unsigned uint64 when both are unsigned, and float64 when
+// either is a float. Mixing a signed and an unsigned operand promotes the
+// signed one to uint64 when it is non-negative; a negative signed operand
+// instead falls back to signed int64 arithmetic, which can overflow if the
+// unsigned operand exceeds math.MaxInt64. String operands are parsed with
+// strconv before dispatch, so callers may pass "3" or "2.5" directly.
+func Arithmetic(a, b interface{}, op rune) (interface{}, error) {
+	na, err := toNumeric(a)
+	if err != nil {
+		return nil, err
+	}
+	nb, err := toNumeric(b)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case na.kind == kindFloat || nb.kind == kindFloat:
+		return arithmeticDispatch(na.asFloat(), nb.asFloat(), op)
+	case na.kind == kindUnsigned && nb.kind == kindUnsigned:
+		return arithmeticDispatch(na.u, nb.u, op)
+	case na.kind == kindSigned && nb.kind == kindSigned:
+		return arithmeticDispatch(na.i, nb.i, op)
+	default:
+		return dispatchMixed(na, nb, op)
+	}
+}
+
+// Add returns a + b.
+func Add(a, b interface{}) (interface{}, error) { return Arithmetic(a, b, OpAdd) }
+
+// Sub returns a - b.
+func Sub(a, b interface{}) (interface{}, error) { return Arithmetic(a, b, OpSub) }
+
+// Mul returns a * b.
+func Mul(a, b interface{}) (interface{}, error) { return Arithmetic(a, b, OpMul) }
+
+// Div returns a / b, or ErrDivideByZero when b is zero. Like the other
+// operators, Div computes in whatever width Arithmetic selects for the
+// operand pair, so dividing two integer operands truncates the result
+// instead of producing a fractional quotient; pass at least one float
+// operand to get real division.
+func Div(a, b interface{}) (interface{}, error) { return Arithmetic(a, b, OpDiv) }
+
+func toNumeric(v interface{}) (numeric, error) {
+	if s, ok := v.(string); ok {
+		return numericFromString(s)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return numeric{kind: kindSigned, i: rv.Int()}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return numeric{kind: kindUnsigned, u: rv.Uint()}, nil
+	case reflect.Float32, reflect.Float64:
+		return numeric{kind: kindFloat, f: rv.Float()}, nil
+	default:
+		return numeric{}, &UnsupportedTypeError{Value: v}
+	}
+}
+
+func numericFromString(s string) (numeric, error) {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return numeric{kind: kindSigned, i: i}, nil
+	}
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return numeric{kind: kindUnsigned, u: u}, nil
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return numeric{kind: kindFloat, f: f}, nil
+	}
+	return numeric{}, &UnsupportedTypeError{Value: s}
+}
+
+// dispatchMixed resolves an operand pair where one side is signed and the
+// other unsigned, preserving a and b's original order (required for the
+// non-commutative operators Sub and Div).
+func dispatchMixed(a, b numeric, op rune) (interface{}, error) {
+	signed := a
+	if signed.kind != kindSigned {
+		signed = b
+	}
+
+	if signed.i >= 0 {
+		return arithmeticDispatch(promoteToUint64(a), promoteToUint64(b), op)
+	}
+	// signed is negative and can't be promoted to uint64, so fall back to
+	// signed arithmetic; this overflows if the unsigned operand exceeds
+	// math.MaxInt64.
+	return arithmeticDispatch(promoteToInt64(a), promoteToInt64(b), op)
+}
+
+func promoteToUint64(n numeric) uint64 {
+	if n.kind == kindSigned {
+		return uint64(n.i)
+	}
+	return n.u
+}
+
+func promoteToInt64(n numeric) int64 {
+	if n.kind == kindUnsigned {
+		return int64(n.u)
+	}
+	return n.i
 }
 
-// Multiply multiplies two integers
-func Multiply(a, b int) int {
-	return a * b
+// signedOrUnsignedOrFloat constrains arithmeticDispatch to the three
+// native widths Arithmetic normalizes operands into.
+type signedOrUnsignedOrFloat interface {
+	~int64 | ~uint64 | ~float64
 }
 
-// Divide divides a by b
-func Divide(a, b float64) float64 {
-	if b == 0 {
-		return 0
+// arithmeticDispatch applies op to a and b in T's width, shared by the
+// signed, unsigned, and float paths so the operator switch is defined once.
+func arithmeticDispatch[T signedOrUnsignedOrFloat](a, b T, op rune) (interface{}, error) {
+	switch op {
+	case OpAdd:
+		return a + b, nil
+	case OpSub:
+		return a - b, nil
+	case OpMul:
+		return a * b, nil
+	case OpDiv:
+		if b == 0 {
+			return nil, ErrDivideByZero
+		}
+		return a / b, nil
+	default:
+		return nil, &UnsupportedOpError{Op: op}
 	}
-	return a / b
 }
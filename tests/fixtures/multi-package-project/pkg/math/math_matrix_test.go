@@ -0,0 +1,102 @@
+package math
+
+import (
+	"math"
+	"testing"
+)
+
+// TestArithmeticMatrix exercises every operand-kind pair against every
+// operator, including the promotion edge cases described on Arithmetic's
+// doc comment.
+func TestArithmeticMatrix(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b interface{}
+		op   rune
+		want interface{}
+	}{
+		{"signed+signed", int32(2), int64(3), OpAdd, int64(5)},
+		{"signed-signed", int8(5), int(3), OpSub, int64(2)},
+		{"signed*signed", int16(3), int32(4), OpMul, int64(12)},
+		{"signed/signed", int(10), int(2), OpDiv, int64(5)},
+
+		{"unsigned+unsigned", uint8(2), uint64(3), OpAdd, uint64(5)},
+		{"unsigned-unsigned", uint(5), uint16(3), OpSub, uint64(2)},
+		{"unsigned*unsigned", uint32(3), uint(4), OpMul, uint64(12)},
+		{"unsigned/unsigned", uint64(10), uint64(2), OpDiv, uint64(5)},
+
+		{"float+float", float32(2.5), float64(1.5), OpAdd, float64(4)},
+		{"float-float", float64(5), float32(1.5), OpSub, float64(3.5)},
+		{"float*float", float64(2), float64(1.5), OpMul, float64(3)},
+		{"float/float", float64(5), float64(2), OpDiv, float64(2.5)},
+
+		{"signed+float promotes to float", int(2), float64(1.5), OpAdd, float64(3.5)},
+		{"signed-float promotes to float", int(5), float64(1.5), OpSub, float64(3.5)},
+		{"signed*float promotes to float", int(3), float64(1.5), OpMul, float64(4.5)},
+		{"signed/float promotes to float", int(5), float64(2), OpDiv, float64(2.5)},
+		{"float+unsigned promotes to float", float64(1.5), uint(2), OpAdd, float64(3.5)},
+		{"float-unsigned promotes to float", float64(5.5), uint(2), OpSub, float64(3.5)},
+		{"float*unsigned promotes to float", float64(1.5), uint(4), OpMul, float64(6)},
+		{"float/unsigned promotes to float", float64(5), uint(2), OpDiv, float64(2.5)},
+
+		{"non-negative signed promotes to unsigned", int(5), uint(3), OpAdd, uint64(8)},
+		{"unsigned operand order matches", uint(3), int(5), OpAdd, uint64(8)},
+		{"signed-unsigned preserves operand order", int32(10), uint32(3), OpSub, uint64(7)},
+		{"unsigned-signed preserves operand order", uint32(10), int32(3), OpSub, uint64(7)},
+		{"signed*unsigned preserves operand order", int(5), uint(4), OpMul, uint64(20)},
+		{"unsigned*signed preserves operand order", uint(5), int(4), OpMul, uint64(20)},
+		{"signed/unsigned preserves operand order", int(10), uint(2), OpDiv, uint64(5)},
+		{"unsigned/signed preserves operand order", uint(10), int(2), OpDiv, uint64(5)},
+
+		{"negative signed falls back to signed, overflowing", int(-1), uint64(math.MaxUint64), OpAdd, int64(-2)},
+		{"unsigned-negative signed preserves operand order", uint(1), int(-1), OpSub, int64(2)},
+
+		{"numeric strings parse before dispatch", "3", "2.5", OpAdd, float64(5.5)},
+		{"signed numeric string", "3", "4", OpAdd, int64(7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Arithmetic(tt.a, tt.b, tt.op)
+			if err != nil {
+				t.Fatalf("Arithmetic(%v, %v, %q) returned error: %v", tt.a, tt.b, tt.op, err)
+			}
+			if got != tt.want {
+				t.Errorf("Arithmetic(%v, %v, %q) = %v (%T), want %v (%T)", tt.a, tt.b, tt.op, got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+func TestArithmeticDivideByZero(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b interface{}
+	}{
+		{"signed", int(1), int(0)},
+		{"unsigned", uint(1), uint(0)},
+		{"float", float64(1), float64(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Arithmetic(tt.a, tt.b, OpDiv); err != ErrDivideByZero {
+				t.Errorf("Arithmetic(%v, %v, '/') error = %v, want ErrDivideByZero", tt.a, tt.b, err)
+			}
+		})
+	}
+}
+
+func TestArithmeticUnsupportedType(t *testing.T) {
+	_, err := Arithmetic(struct{}{}, 1, OpAdd)
+	if _, ok := err.(*UnsupportedTypeError); !ok {
+		t.Errorf("Arithmetic(struct{}{}, 1, '+') error = %v (%T), want *UnsupportedTypeError", err, err)
+	}
+}
+
+func TestArithmeticUnsupportedOp(t *testing.T) {
+	_, err := Arithmetic(1, 2, '%')
+	if _, ok := err.(*UnsupportedOpError); !ok {
+		t.Errorf("Arithmetic(1, 2, '%%') error = %v (%T), want *UnsupportedOpError", err, err)
+	}
+}
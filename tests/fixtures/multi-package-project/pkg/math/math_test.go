@@ -3,30 +3,47 @@ package math
 import "testing"
 
 func TestAdd(t *testing.T) {
-	if Add(2, 3) != 5 {
-		t.Error("Add(2, 3) should equal 5")
+	got, err := Add(2, 3)
+	if err != nil {
+		t.Fatalf("Add(2, 3) returned error: %v", err)
+	}
+	if got != int64(5) {
+		t.Errorf("Add(2, 3) = %v, want 5", got)
 	}
 }
 
-func TestSubtract(t *testing.T) {
-	if Subtract(5, 3) != 2 {
-		t.Error("Subtract(5, 3) should equal 2")
+func TestSub(t *testing.T) {
+	got, err := Sub(5, 3)
+	if err != nil {
+		t.Fatalf("Sub(5, 3) returned error: %v", err)
+	}
+	if got != int64(2) {
+		t.Errorf("Sub(5, 3) = %v, want 2", got)
 	}
 }
 
-func TestMultiply(t *testing.T) {
-	if Multiply(3, 4) != 12 {
-		t.Error("Multiply(3, 4) should equal 12")
+func TestMul(t *testing.T) {
+	got, err := Mul(3, 4)
+	if err != nil {
+		t.Fatalf("Mul(3, 4) returned error: %v", err)
+	}
+	if got != int64(12) {
+		t.Errorf("Mul(3, 4) = %v, want 12", got)
 	}
 }
 
-func TestDivide(t *testing.T) {
-	if Divide(10.0, 2.0) != 5.0 {
-		t.Error("Divide(10.0, 2.0) should equal 5.0")
+func TestDiv(t *testing.T) {
+	got, err := Div(10.0, 2.0)
+	if err != nil {
+		t.Fatalf("Div(10.0, 2.0) returned error: %v", err)
 	}
+	if got != 5.0 {
+		t.Errorf("Div(10.0, 2.0) = %v, want 5.0", got)
+	}
+}
 
-	// Test division by zero
-	if Divide(10.0, 0.0) != 0.0 {
-		t.Error("Divide(10.0, 0.0) should equal 0.0")
+func TestDivByZero(t *testing.T) {
+	if _, err := Div(10.0, 0.0); err != ErrDivideByZero {
+		t.Errorf("Div(10.0, 0.0) error = %v, want ErrDivideByZero", err)
 	}
 }